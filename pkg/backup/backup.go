@@ -0,0 +1,161 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+// Package backup snapshots and restores hpecli's saved provider logins,
+// so operators can move state between workstations or capture a known
+// good state before running destructive operations across many
+// appliances.
+package backup
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/HewlettPackard/hpecli/pkg/context"
+)
+
+// manifestVersion is bumped whenever the on-disk backup layout changes in
+// a way Restore needs to know about.
+const manifestVersion = 1
+
+const (
+	manifestFile = "manifest.json"
+	sessionsFile = "sessions.enc"
+)
+
+// manifest describes a backup directory: the format it was written with,
+// when, and what's needed to decrypt sessionsFile.
+type manifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	Salt      string    `json:"salt"`
+	Nonce     string    `json:"nonce"`
+}
+
+// Run backs up every saved provider login into dir, encrypting it with a
+// key derived from passphrase. dir must not already exist; the backup is
+// written to a sibling temp directory first and renamed into place so a
+// reader never observes a partial backup.
+func Run(dir, passphrase string) error {
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("%s already exists; remove it or choose another directory", dir)
+	}
+
+	entries, err := context.List()
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	salt, nonce, ciphertext, err := seal(passphrase, plaintext)
+	if err != nil {
+		return err
+	}
+
+	m := manifest{
+		Version:   manifestVersion,
+		CreatedAt: time.Now(),
+		Salt:      hex.EncodeToString(salt),
+		Nonce:     hex.EncodeToString(nonce),
+	}
+
+	return writeAtomically(dir, m, ciphertext)
+}
+
+// Restore repopulates the shared context store from a backup directory
+// written by Run.
+func Restore(dir, passphrase string) error {
+	m, ciphertext, err := readBackup(dir)
+	if err != nil {
+		return err
+	}
+
+	if m.Version != manifestVersion {
+		return fmt.Errorf("backup at %s is manifest version %d, this hpecli only understands version %d",
+			dir, m.Version, manifestVersion)
+	}
+
+	salt, err := hex.DecodeString(m.Salt)
+	if err != nil {
+		return fmt.Errorf("backup manifest has an invalid salt: %v", err)
+	}
+
+	nonce, err := hex.DecodeString(m.Nonce)
+	if err != nil {
+		return fmt.Errorf("backup manifest has an invalid nonce: %v", err)
+	}
+
+	plaintext, err := open(passphrase, salt, nonce, ciphertext)
+	if err != nil {
+		return err
+	}
+
+	var entries []context.Entry
+	if err := json.Unmarshal(plaintext, &entries); err != nil {
+		return fmt.Errorf("backup contents are corrupt: %v", err)
+	}
+
+	return context.ReplaceAll(entries)
+}
+
+func readBackup(dir string) (manifest, []byte, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		return manifest{}, nil, fmt.Errorf("unable to read backup manifest: %v", err)
+	}
+
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return manifest{}, nil, fmt.Errorf("backup manifest is corrupt: %v", err)
+	}
+
+	ciphertext, err := ioutil.ReadFile(filepath.Join(dir, sessionsFile))
+	if err != nil {
+		return manifest{}, nil, fmt.Errorf("unable to read backup contents: %v", err)
+	}
+
+	return m, ciphertext, nil
+}
+
+func writeAtomically(dir string, m manifest, ciphertext []byte) error {
+	parent := filepath.Dir(dir)
+	if err := os.MkdirAll(parent, 0o700); err != nil {
+		return err
+	}
+
+	tmpDir, err := ioutil.TempDir(parent, ".hpecli-backup-*")
+	if err != nil {
+		return err
+	}
+
+	manifestJSON, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return err
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(tmpDir, manifestFile), manifestJSON, 0o600); err != nil {
+		os.RemoveAll(tmpDir)
+		return err
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(tmpDir, sessionsFile), ciphertext, 0o600); err != nil {
+		os.RemoveAll(tmpDir)
+		return err
+	}
+
+	if err := os.Rename(tmpDir, dir); err != nil {
+		os.RemoveAll(tmpDir)
+		return fmt.Errorf("unable to install backup at %s: %v", dir, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+package backup
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/HewlettPackard/hpecli/pkg/context"
+)
+
+func TestSealOpenRoundTrip(t *testing.T) {
+	plaintext := []byte(`{"entries":[{"name":"oneview/host1"}]}`)
+
+	salt, nonce, ciphertext, err := seal("correct-horse", plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := open("correct-horse", salt, nonce, ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != string(plaintext) {
+		t.Fatalf("got %q, want %q", got, plaintext)
+	}
+}
+
+func TestOpenWrongPassphraseFails(t *testing.T) {
+	salt, nonce, ciphertext, err := seal("correct-horse", []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := open("wrong-passphrase", salt, nonce, ciphertext); err == nil {
+		t.Fatal("expected an error decrypting with the wrong passphrase")
+	}
+}
+
+func TestRunAndRestoreRoundTrip(t *testing.T) {
+	context.SetStoreDirForTesting(t.TempDir())
+
+	if err := context.Save(context.Entry{Provider: "oneview", Host: "https://ov1", Token: "tok1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "backup")
+	if err := Run(dir, "s3cret"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := context.Remove("oneview", "https://ov1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Restore(dir, "s3cret"); err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := context.Get("oneview", "https://ov1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Token != "tok1" {
+		t.Fatalf("got token %q after restore, want %q", e.Token, "tok1")
+	}
+}
+
+func TestRunRefusesToOverwriteExistingDir(t *testing.T) {
+	context.SetStoreDirForTesting(t.TempDir())
+
+	dir := t.TempDir()
+	if err := Run(dir, "s3cret"); err == nil {
+		t.Fatal("expected an error backing up into an existing directory")
+	}
+}
+
+func TestRestoreRejectsUnknownManifestVersion(t *testing.T) {
+	context.SetStoreDirForTesting(t.TempDir())
+
+	dir := filepath.Join(t.TempDir(), "backup")
+	if err := Run(dir, "s3cret"); err != nil {
+		t.Fatal(err)
+	}
+
+	bumpManifestVersion(t, dir)
+
+	if err := Restore(dir, "s3cret"); err == nil {
+		t.Fatal("expected an error restoring a backup with an unknown manifest version")
+	}
+}
+
+func bumpManifestVersion(t *testing.T, dir string) {
+	t.Helper()
+
+	path := filepath.Join(dir, manifestFile)
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+
+	m.Version = manifestVersion + 1
+
+	data, err = json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,86 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+package backup
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// NewBackupCommand builds the "backup" root command.
+func NewBackupCommand() *cobra.Command {
+	var passphrase string
+
+	cmd := &cobra.Command{
+		Use:   "backup <dir>",
+		Short: "Encrypt and save every saved provider login to a directory",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			p, err := resolvePassphrase(passphrase)
+			if err != nil {
+				return err
+			}
+
+			if err := Run(args[0], p); err != nil {
+				return err
+			}
+
+			fmt.Printf("Backed up saved logins to %s\n", args[0])
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&passphrase, "passphrase", "", "passphrase to encrypt the backup with (prompted for if omitted)")
+
+	return cmd
+}
+
+// NewRestoreCommand builds the "restore" root command.
+func NewRestoreCommand() *cobra.Command {
+	var passphrase string
+
+	cmd := &cobra.Command{
+		Use:   "restore <dir>",
+		Short: "Restore saved provider logins from a backup directory",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			p, err := resolvePassphrase(passphrase)
+			if err != nil {
+				return err
+			}
+
+			if err := Restore(args[0], p); err != nil {
+				return err
+			}
+
+			fmt.Printf("Restored saved logins from %s\n", args[0])
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&passphrase, "passphrase", "", "passphrase the backup was encrypted with (prompted for if omitted)")
+
+	return cmd
+}
+
+func resolvePassphrase(flagValue string) (string, error) {
+	if flagValue != "" {
+		return flagValue, nil
+	}
+
+	fmt.Print("Passphrase: ")
+
+	b, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+
+	if err != nil {
+		return "", fmt.Errorf("unable to read passphrase: %v", err)
+	}
+
+	return string(b), nil
+}
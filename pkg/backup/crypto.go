@@ -0,0 +1,77 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+package backup
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	saltSize = 16
+	keySize  = 32
+
+	argonTime     = 1
+	argonMemoryKB = 64 * 1024
+	argonThreads  = 4
+)
+
+// deriveKey stretches passphrase into a 256-bit AES key using argon2id,
+// the password-hashing variant resistant to both GPU and side-channel
+// attacks.
+func deriveKey(passphrase string, salt []byte) []byte {
+	return argon2.IDKey([]byte(passphrase), salt, argonTime, argonMemoryKB, argonThreads, keySize)
+}
+
+// seal encrypts plaintext with a key derived from passphrase, returning
+// the random salt and nonce alongside the ciphertext so decryption can
+// reproduce the key and GCM state.
+func seal(passphrase string, plaintext []byte) (salt, nonce, ciphertext []byte, err error) {
+	salt = make([]byte, saltSize)
+	if _, err = rand.Read(salt); err != nil {
+		return nil, nil, nil, err
+	}
+
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	nonce = make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, nil, nil, err
+	}
+
+	ciphertext = gcm.Seal(nil, nonce, plaintext, nil)
+
+	return salt, nonce, ciphertext, nil
+}
+
+// open decrypts ciphertext with a key derived from passphrase and the
+// stored salt/nonce.
+func open(passphrase string, salt, nonce, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt backup: wrong passphrase or corrupt data")
+	}
+
+	return plaintext, nil
+}
+
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(deriveKey(passphrase, salt))
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,34 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+package cloudvolume
+
+import (
+	sharedcontext "github.com/HewlettPackard/hpecli/pkg/context"
+)
+
+const providerName = "cloudvolume"
+
+// saveHostData records a successful CloudVolumes login in the shared
+// context store, making it the current one.
+func saveHostData(host, apiKey string) error {
+	return sharedcontext.Save(sharedcontext.Entry{
+		Provider: providerName,
+		Host:     host,
+		Token:    apiKey,
+	})
+}
+
+// hostData returns the saved API key for host.
+func hostData(host string) (apiKey string, err error) {
+	e, err := sharedcontext.Get(providerName, host)
+	if err != nil {
+		return "", err
+	}
+
+	return e.Token, nil
+}
+
+// deleteSavedHostData removes the saved login for host.
+func deleteSavedHostData(host string) error {
+	return sharedcontext.Remove(providerName, host)
+}
@@ -6,7 +6,7 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/sirupsen/logrus"
+	"github.com/HewlettPackard/hpecli/pkg/logger"
 	"github.com/spf13/cobra"
 )
 
@@ -29,34 +29,33 @@ func newLogoutCommand() *cobra.Command {
 		},
 	}
 
-
 	return cmd
 }
 
 func runLogout(host string) error {
-	logrus.Debug("Beginning runCloudVolumeLogout")
-	
+	logger.Debug("Beginning runCloudVolumeLogout")
+
 	if host == "" {
 		host = cvDefaultHost
 	}
 	token, err := hostData(host)
 	if err != nil {
-		logrus.Debugf("unable to retrieve apiKey because of: %v", err)
+		logger.Debug("unable to retrieve apiKey because of: %v", err)
 		return fmt.Errorf("Unable to retrieve the last login for HPE Cloud volumes. " +
 			"Please login to HPE Cloud Volumes using: hpe cloudvolumes login")
 	}
 
-	//logrus.Warningf("Using CloudVolumes: %s", host)
+	logger.Warning("Using CloudVolumes: %s", host)
 
 	_ = newCVClientFromAPIKey(host, token)
 
 	// There is no API logout we can use
-	logrus.Infof("Successfully logged out of HPE CloudVolumes")
+	logger.Info("Successfully logged out of HPE CloudVolumes")
 
 	// Cleanup context
 	err = deleteSavedHostData(host)
 	if err != nil {
-		logrus.Warning("Unable to cleanup the session data")
+		logger.Warning("Unable to cleanup the session data")
 		return err
 	}
 
@@ -0,0 +1,89 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+package context
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// NewCommand builds the "context" command and its list/use/rm
+// subcommands. It is registered on the root command alongside oneview,
+// cloudvolume and greenlake.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "context",
+		Short: "List and switch between saved provider logins",
+	}
+
+	cmd.AddCommand(newListCommand())
+	cmd.AddCommand(newUseCommand())
+	cmd.AddCommand(newRmCommand())
+
+	return cmd
+}
+
+func newListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List every saved login across all providers",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			entries, err := List()
+			if err != nil {
+				return err
+			}
+
+			if len(entries) == 0 {
+				fmt.Println("No saved logins")
+				return nil
+			}
+
+			for _, e := range entries {
+				fmt.Printf("%s\tprovider=%s\thost=%s\n", e.Name, e.Provider, e.Host)
+			}
+
+			return nil
+		},
+	}
+}
+
+func newUseCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <name>",
+		Short: "Make a saved login the current one for its provider",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			e, err := Use(args[0])
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Now using %s (%s)\n", e.Name, e.Host)
+
+			return nil
+		},
+	}
+}
+
+func newRmCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rm <name>",
+		Short: "Remove a saved login",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			entries, err := List()
+			if err != nil {
+				return err
+			}
+
+			for _, e := range entries {
+				if e.Name == args[0] {
+					return Remove(e.Provider, e.Host)
+				}
+			}
+
+			return fmt.Errorf("no saved login named %q", args[0])
+		},
+	}
+}
@@ -0,0 +1,42 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+package context
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func lockFilePath() string {
+	return filepath.Join(storeDir, ".context.lock")
+}
+
+// acquireLock takes an exclusive, interprocess lock on the store
+// directory. mu only guards against concurrent goroutines within one
+// hpecli process; every invocation of the CLI is a separate process
+// with its own zero-value mu, so it can't stop two of them from
+// interleaving a load and a persist. The OS-level lock on this file
+// can.
+func acquireLock() (*os.File, error) {
+	if err := os.MkdirAll(storeDir, 0o700); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(lockFilePath(), os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open context store lock file: %v", err)
+	}
+
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("unable to lock context store: %v", err)
+	}
+
+	return f, nil
+}
+
+func releaseLock(f *os.File) {
+	unlockFile(f)
+	f.Close()
+}
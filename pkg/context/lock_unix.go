@@ -0,0 +1,18 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+//go:build !windows
+
+package context
+
+import (
+	"os"
+	"syscall"
+)
+
+func lockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+}
+
+func unlockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
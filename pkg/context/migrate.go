@@ -0,0 +1,51 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+package context
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+)
+
+// legacyHostFile is the single-host state each provider kept before the
+// shared store existed: one JSON file per provider holding whatever it
+// needed to log back out again.
+type legacyHostFile struct {
+	Host     string `json:"host"`
+	Token    string `json:"token"`
+	TenantID string `json:"tenantId"`
+}
+
+// legacyProviders are the providers that may still have a pre-migration,
+// single-host file on disk.
+var legacyProviders = []string{"oneview", "cloudvolume", "greenlake"}
+
+// migrateLegacy imports each provider's old single-host file into fs, the
+// first time the shared store is read on a machine that never had one.
+// It is best effort: a missing or unreadable legacy file is simply
+// skipped rather than treated as an error, since most users will have
+// none at all.
+func migrateLegacy(fs *fileStore) {
+	for _, provider := range legacyProviders {
+		path := filepath.Join(storeDir, provider+".json")
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		var legacy legacyHostFile
+		if err := json.Unmarshal(data, &legacy); err != nil || legacy.Host == "" {
+			continue
+		}
+
+		fs.Entries = append(fs.Entries, Entry{
+			Name:     entryName(provider, legacy.Host),
+			Provider: provider,
+			Host:     legacy.Host,
+			Token:    legacy.Token,
+			TenantID: legacy.TenantID,
+		})
+	}
+}
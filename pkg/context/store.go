@@ -0,0 +1,320 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+// Package context is the shared session store for hpecli. Every provider
+// (oneview, cloudvolume, greenlake, ...) saves its logins here instead of
+// keeping its own single-host file, so a user can be logged into several
+// appliances/tenants at once and switch between them with
+// "hpecli context use".
+package context
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Entry is one saved login for a single provider+host pair.
+type Entry struct {
+	Name      string    `json:"name"`
+	Provider  string    `json:"provider"`
+	Host      string    `json:"host"`
+	Token     string    `json:"token"`
+	TenantID  string    `json:"tenantId,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+}
+
+// fileStore is the on-disk shape of the store: every saved entry, plus
+// the current entry name for each provider. Current is keyed by
+// provider rather than a single shared name so selecting one with Use
+// doesn't clobber another provider's independent selection.
+type fileStore struct {
+	Entries []Entry           `json:"entries"`
+	Current map[string]string `json:"current,omitempty"`
+}
+
+var mu sync.Mutex
+
+// storeDir is a var so tests can point it at a temp directory.
+var storeDir = defaultStoreDir()
+
+func defaultStoreDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+
+	return filepath.Join(home, ".hpecli")
+}
+
+func storePath() string {
+	return filepath.Join(storeDir, "context.json")
+}
+
+func entryName(provider, host string) string {
+	return provider + "/" + host
+}
+
+// Save adds or updates the entry for e.Provider+e.Host and makes it the
+// current entry for that provider.
+func Save(e Entry) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	lock, err := acquireLock()
+	if err != nil {
+		return err
+	}
+	defer releaseLock(lock)
+
+	fs, err := load()
+	if err != nil {
+		return err
+	}
+
+	e.Name = entryName(e.Provider, e.Host)
+
+	replaced := false
+	for i, existing := range fs.Entries {
+		if existing.Name == e.Name {
+			fs.Entries[i] = e
+			replaced = true
+			break
+		}
+	}
+
+	if !replaced {
+		fs.Entries = append(fs.Entries, e)
+	}
+
+	if fs.Current == nil {
+		fs.Current = map[string]string{}
+	}
+	fs.Current[e.Provider] = e.Name
+
+	return persist(fs)
+}
+
+// Get returns the saved entry for provider+host.
+func Get(provider, host string) (Entry, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	lock, err := acquireLock()
+	if err != nil {
+		return Entry{}, err
+	}
+	defer releaseLock(lock)
+
+	fs, err := load()
+	if err != nil {
+		return Entry{}, err
+	}
+
+	name := entryName(provider, host)
+	for _, e := range fs.Entries {
+		if e.Name == name {
+			return e, nil
+		}
+	}
+
+	return Entry{}, fmt.Errorf("no saved session for %s %s", provider, host)
+}
+
+// Current returns the entry last selected for provider by Save or Use.
+// If none is selected, it falls back to the last entry for that provider
+// in store order; a Save that updates an existing entry does not move it.
+func Current(provider string) (Entry, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	lock, err := acquireLock()
+	if err != nil {
+		return Entry{}, err
+	}
+	defer releaseLock(lock)
+
+	fs, err := load()
+	if err != nil {
+		return Entry{}, err
+	}
+
+	if current := fs.Current[provider]; current != "" {
+		for _, e := range fs.Entries {
+			if e.Name == current && e.Provider == provider {
+				return e, nil
+			}
+		}
+	}
+
+	for i := len(fs.Entries) - 1; i >= 0; i-- {
+		if fs.Entries[i].Provider == provider {
+			return fs.Entries[i], nil
+		}
+	}
+
+	return Entry{}, fmt.Errorf("no saved session for provider %s", provider)
+}
+
+// Remove deletes the entry for provider+host.
+func Remove(provider, host string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	lock, err := acquireLock()
+	if err != nil {
+		return err
+	}
+	defer releaseLock(lock)
+
+	fs, err := load()
+	if err != nil {
+		return err
+	}
+
+	name := entryName(provider, host)
+
+	kept := fs.Entries[:0]
+	for _, e := range fs.Entries {
+		if e.Name != name {
+			kept = append(kept, e)
+		}
+	}
+	fs.Entries = kept
+
+	if fs.Current[provider] == name {
+		delete(fs.Current, provider)
+	}
+
+	return persist(fs)
+}
+
+// List returns every saved entry, across all providers.
+func List() ([]Entry, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	lock, err := acquireLock()
+	if err != nil {
+		return nil, err
+	}
+	defer releaseLock(lock)
+
+	fs, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	return fs.Entries, nil
+}
+
+// ReplaceAll atomically replaces every saved entry with entries. It is
+// used by "hpecli restore" to repopulate the store from a backup.
+func ReplaceAll(entries []Entry) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	lock, err := acquireLock()
+	if err != nil {
+		return err
+	}
+	defer releaseLock(lock)
+
+	return persist(&fileStore{Entries: entries})
+}
+
+// Use marks the entry named name (provider/host, as shown by List) as
+// current for its provider, and returns it.
+func Use(name string) (Entry, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	lock, err := acquireLock()
+	if err != nil {
+		return Entry{}, err
+	}
+	defer releaseLock(lock)
+
+	fs, err := load()
+	if err != nil {
+		return Entry{}, err
+	}
+
+	for _, e := range fs.Entries {
+		if e.Name == name {
+			if fs.Current == nil {
+				fs.Current = map[string]string{}
+			}
+			fs.Current[e.Provider] = name
+
+			if err := persist(fs); err != nil {
+				return Entry{}, err
+			}
+
+			return e, nil
+		}
+	}
+
+	return Entry{}, fmt.Errorf("no saved session named %q", name)
+}
+
+func load() (*fileStore, error) {
+	fs := &fileStore{}
+
+	data, err := ioutil.ReadFile(storePath())
+	if os.IsNotExist(err) {
+		migrateLegacy(fs)
+		return fs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, fs); err != nil {
+		return nil, fmt.Errorf("context store at %s is corrupt: %v", storePath(), err)
+	}
+
+	return fs, nil
+}
+
+// persist writes fs to disk atomically: write to a tempfile in the same
+// directory, fsync it, then rename over the real store path so a reader
+// never observes a partially written file.
+func persist(fs *fileStore) error {
+	if err := os.MkdirAll(storeDir, 0o700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(fs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(storeDir, "context-*.json")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return os.Rename(tmpPath, storePath())
+}
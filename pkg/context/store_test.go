@@ -0,0 +1,162 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+package context
+
+import (
+	"testing"
+)
+
+func withTempStore(t *testing.T) {
+	t.Helper()
+
+	SetStoreDirForTesting(t.TempDir())
+}
+
+func TestSaveAndGet(t *testing.T) {
+	withTempStore(t)
+
+	if err := Save(Entry{Provider: "oneview", Host: "https://ov1", Token: "tok1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := Get("oneview", "https://ov1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Token != "tok1" {
+		t.Fatalf("got token %q, want %q", e.Token, "tok1")
+	}
+}
+
+func TestSaveUpdatesExistingEntry(t *testing.T) {
+	withTempStore(t)
+
+	if err := Save(Entry{Provider: "oneview", Host: "https://ov1", Token: "tok1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Save(Entry{Provider: "oneview", Host: "https://ov1", Token: "tok2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+
+	if entries[0].Token != "tok2" {
+		t.Fatalf("got token %q, want %q", entries[0].Token, "tok2")
+	}
+}
+
+func TestCurrentIsMostRecentlySavedForProvider(t *testing.T) {
+	withTempStore(t)
+
+	if err := Save(Entry{Provider: "oneview", Host: "https://ov1", Token: "tok1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Save(Entry{Provider: "cloudvolume", Host: "https://cv1", Token: "cvtok"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Save(Entry{Provider: "oneview", Host: "https://ov2", Token: "tok2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := Current("oneview")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Host != "https://ov2" {
+		t.Fatalf("got current host %q, want %q", e.Host, "https://ov2")
+	}
+}
+
+func TestUseSwitchesCurrent(t *testing.T) {
+	withTempStore(t)
+
+	if err := Save(Entry{Provider: "oneview", Host: "https://ov1", Token: "tok1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Save(Entry{Provider: "oneview", Host: "https://ov2", Token: "tok2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Use("oneview/https://ov1"); err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := Current("oneview")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Host != "https://ov1" {
+		t.Fatalf("got current host %q after Use, want %q", e.Host, "https://ov1")
+	}
+}
+
+func TestUseIsIndependentPerProvider(t *testing.T) {
+	withTempStore(t)
+
+	if err := Save(Entry{Provider: "oneview", Host: "https://ov1", Token: "tok1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Save(Entry{Provider: "oneview", Host: "https://ov2", Token: "tok2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Use("oneview/https://ov1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Save(Entry{Provider: "cloudvolume", Host: "https://cv1", Token: "cvtok"}); err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := Current("oneview")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Host != "https://ov1" {
+		t.Fatalf("saving a cloudvolume entry clobbered oneview's explicit Use: got current host %q, want %q", e.Host, "https://ov1")
+	}
+
+	cv, err := Current("cloudvolume")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cv.Host != "https://cv1" {
+		t.Fatalf("got current cloudvolume host %q, want %q", cv.Host, "https://cv1")
+	}
+}
+
+func TestRemove(t *testing.T) {
+	withTempStore(t)
+
+	if err := Save(Entry{Provider: "oneview", Host: "https://ov1", Token: "tok1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Remove("oneview", "https://ov1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Get("oneview", "https://ov1"); err == nil {
+		t.Fatal("expected an error getting a removed entry")
+	}
+}
+
+func TestGetMissingEntryErrors(t *testing.T) {
+	withTempStore(t)
+
+	if _, err := Get("oneview", "https://nope"); err == nil {
+		t.Fatal("expected an error for a host with no saved login")
+	}
+}
@@ -0,0 +1,10 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+package context
+
+// SetStoreDirForTesting points the shared store at dir, so tests (in
+// this package or others, e.g. pkg/backup) never read or write a real
+// user's saved logins. It is exported only for test use.
+func SetStoreDirForTesting(dir string) {
+	storeDir = dir
+}
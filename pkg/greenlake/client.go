@@ -0,0 +1,127 @@
+//(C) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package greenlake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// User is a single entry returned by the RedFish Users collection.
+type User struct {
+	DisplayName string `json:"displayName"`
+	UserName    string `json:"userName"`
+	Active      bool   `json:"active"`
+}
+
+// GLClient issues authenticated RedFish requests against a GreenLake
+// tenant.
+type GLClient struct {
+	host     string
+	tenantID string
+	apiKey   string
+}
+
+// NewGLClientFromAPIKey builds a GLClient that authenticates with a
+// previously obtained API key.
+func NewGLClientFromAPIKey(host, tenantID, apiKey string) *GLClient {
+	return &GLClient{host: host, tenantID: tenantID, apiKey: apiKey}
+}
+
+// GetOptions carries the optional RedFish query parameters that narrow a
+// Get response.
+type GetOptions struct {
+	Select string
+	Filter string
+}
+
+const odataNextLink = "@odata.nextLink"
+
+// Get fetches an arbitrary RedFish resource path, e.g. "Users",
+// "Systems/1/Processors" or "Chassis/1/Thermal", following
+// @odata.nextLink pagination and merging every page's "value" member into
+// a single response. The returned bytes are the raw (merged) JSON body.
+func (c *GLClient) Get(path string, opts GetOptions) ([]byte, error) {
+	next := c.resourceURL(path, opts)
+
+	var merged map[string]interface{}
+
+	for next != "" {
+		page, err := c.getRaw(next)
+		if err != nil {
+			return nil, err
+		}
+
+		var decoded map[string]interface{}
+		if err := json.Unmarshal(page, &decoded); err != nil {
+			return nil, fmt.Errorf("unable to parse RedFish response from %s: %v", next, err)
+		}
+
+		if merged == nil {
+			merged = decoded
+		} else if values, ok := decoded["value"].([]interface{}); ok {
+			existing, _ := merged["value"].([]interface{})
+			merged["value"] = append(existing, values...)
+		}
+
+		next, _ = decoded[odataNextLink].(string)
+	}
+
+	delete(merged, odataNextLink)
+
+	return json.Marshal(merged)
+}
+
+// GetUsers fetches the Users collection at path.
+func (c *GLClient) GetUsers(path string) ([]byte, error) {
+	return c.Get(path, GetOptions{})
+}
+
+func (c *GLClient) resourceURL(path string, opts GetOptions) string {
+	base := strings.TrimRight(c.host, "/") + "/redfish/v1/" + strings.TrimLeft(path, "/")
+
+	query := url.Values{}
+	if opts.Select != "" {
+		query.Set("$select", opts.Select)
+	}
+	if opts.Filter != "" {
+		query.Set("$filter", opts.Filter)
+	}
+
+	if len(query) == 0 {
+		return base
+	}
+
+	return base + "?" + query.Encode()
+}
+
+func (c *GLClient) getRaw(fullOrRelativeURL string) ([]byte, error) {
+	reqURL := fullOrRelativeURL
+	if !strings.HasPrefix(reqURL, "http") {
+		reqURL = strings.TrimRight(c.host, "/") + reqURL
+	}
+
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("X-Tenant-Id", c.tenantID)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d retrieving %s", resp.StatusCode, reqURL)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
@@ -0,0 +1,76 @@
+//(C) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package greenlake
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetMergesPaginatedPages(t *testing.T) {
+	var mux http.ServeMux
+	server := httptest.NewServer(&mux)
+	defer server.Close()
+
+	mux.HandleFunc("/redfish/v1/Users", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			w.Write([]byte(`{"value":[{"userName":"bob"}]}`))
+			return
+		}
+		w.Write([]byte(`{"value":[{"userName":"alice"}],"@odata.nextLink":"/redfish/v1/Users?page=2"}`))
+	})
+
+	c := NewGLClientFromAPIKey(server.URL, "tenant", "key")
+
+	body, err := c.Get("Users", GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded struct {
+		Value []struct {
+			UserName string `json:"userName"`
+		} `json:"value"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(decoded.Value) != 2 {
+		t.Fatalf("got %d merged users, want 2", len(decoded.Value))
+	}
+
+	if decoded.Value[0].UserName != "alice" || decoded.Value[1].UserName != "bob" {
+		t.Fatalf("got users %+v, want alice then bob", decoded.Value)
+	}
+
+	if strings.Contains(string(body), odataNextLink) {
+		t.Fatal("merged response should not still carry @odata.nextLink")
+	}
+}
+
+func TestResourceURLEncodesSelectAndFilter(t *testing.T) {
+	c := NewGLClientFromAPIKey("https://gl.example.com/", "tenant", "key")
+
+	got := c.resourceURL("Users", GetOptions{Select: "Name,Status", Filter: "Status/State eq 'Enabled'"})
+
+	want := "https://gl.example.com/redfish/v1/Users?%24filter=Status%2FState+eq+%27Enabled%27&%24select=Name%2CStatus"
+	if got != want {
+		t.Fatalf("got url %q, want %q", got, want)
+	}
+}
+
+func TestResourceURLWithNoOptions(t *testing.T) {
+	c := NewGLClientFromAPIKey("https://gl.example.com", "tenant", "key")
+
+	got := c.resourceURL("Chassis/1", GetOptions{})
+
+	want := "https://gl.example.com/redfish/v1/Chassis/1"
+	if got != want {
+		t.Fatalf("got url %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,31 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+package greenlake
+
+import (
+	sharedcontext "github.com/HewlettPackard/hpecli/pkg/context"
+)
+
+const providerName = "greenlake"
+
+// saveHostData records a successful GreenLake login in the shared
+// context store, making it the current one.
+func saveHostData(host, tenantID, apiKey string) error {
+	return sharedcontext.Save(sharedcontext.Entry{
+		Provider: providerName,
+		Host:     host,
+		TenantID: tenantID,
+		Token:    apiKey,
+	})
+}
+
+// getTokenTenantID returns the host, tenant ID and API key for the
+// current GreenLake login.
+func getTokenTenantID() (host, tenantID, apiKey string) {
+	e, err := sharedcontext.Current(providerName)
+	if err != nil {
+		return "", "", ""
+	}
+
+	return e.Host, e.TenantID, e.Token
+}
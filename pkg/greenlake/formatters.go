@@ -0,0 +1,148 @@
+//(C) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package greenlake
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// formatter renders a RedFish JSON body to stdout as a human readable
+// table. It is chosen by matching the response's "@odata.type".
+type formatter func(body []byte) error
+
+// formatters is keyed by a substring of "@odata.type", since collection
+// types are versioned, e.g. "#UserCollection.UserCollection". Unknown
+// types fall back to formatGeneric.
+var formatters = map[string]formatter{
+	"UserCollection":           formatUsers,
+	"ComputerSystemCollection": formatSystems,
+	"ChassisCollection":        formatChassis,
+	"ManagerCollection":        formatManagers,
+}
+
+// formatResult prints body as a table using the formatter registered for
+// its "@odata.type", or as a generic key/value tree if none matches.
+func formatResult(body []byte) error {
+	odataType := odataType(body)
+
+	for key, f := range formatters {
+		if strings.Contains(odataType, key) {
+			return f(body)
+		}
+	}
+
+	return formatGeneric(body)
+}
+
+func odataType(body []byte) string {
+	var envelope struct {
+		ODataType string `json:"@odata.type"`
+	}
+
+	_ = json.Unmarshal(body, &envelope)
+
+	return envelope.ODataType
+}
+
+func collectionMembers(body []byte) ([]map[string]interface{}, error) {
+	var collection struct {
+		Value []map[string]interface{} `json:"value"`
+	}
+
+	if err := json.Unmarshal(body, &collection); err != nil {
+		return nil, fmt.Errorf("unable to parse RedFish collection: %v", err)
+	}
+
+	return collection.Value, nil
+}
+
+func formatUsers(body []byte) error {
+	var collection struct {
+		Value []User `json:"value"`
+	}
+
+	if err := json.Unmarshal(body, &collection); err != nil {
+		return fmt.Errorf("unable to parse RedFish users collection: %v", err)
+	}
+
+	for _, user := range collection.Value {
+		fmt.Printf("Name: %s : Email: %s Active: %t\n", user.DisplayName, user.UserName, user.Active)
+	}
+
+	return nil
+}
+
+func formatSystems(body []byte) error {
+	members, err := collectionMembers(body)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range members {
+		fmt.Printf("Name: %v : Model: %v : Power: %v\n", m["Name"], m["Model"], m["PowerState"])
+	}
+
+	return nil
+}
+
+func formatChassis(body []byte) error {
+	members, err := collectionMembers(body)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range members {
+		fmt.Printf("Name: %v : ChassisType: %v\n", m["Name"], m["ChassisType"])
+	}
+
+	return nil
+}
+
+func formatManagers(body []byte) error {
+	members, err := collectionMembers(body)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range members {
+		fmt.Printf("Name: %v : FirmwareVersion: %v\n", m["Name"], m["FirmwareVersion"])
+	}
+
+	return nil
+}
+
+// formatGeneric renders an unrecognized RedFish resource as an indented
+// key/value tree, sorted for stable output.
+func formatGeneric(body []byte) error {
+	var tree map[string]interface{}
+	if err := json.Unmarshal(body, &tree); err != nil {
+		return fmt.Errorf("unable to parse RedFish response: %v", err)
+	}
+
+	printTree(tree, 0)
+
+	return nil
+}
+
+func printTree(node map[string]interface{}, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	keys := make([]string, 0, len(node))
+	for k := range node {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		switch v := node[k].(type) {
+		case map[string]interface{}:
+			fmt.Printf("%s%s:\n", indent, k)
+			printTree(v, depth+1)
+		default:
+			fmt.Printf("%s%s: %v\n", indent, k, v)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+//(C) Copyright 2019 Hewlett Packard Enterprise Development LP
+
+package greenlake
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// captureStdout runs f with os.Stdout redirected to a pipe and returns
+// everything written to it, so formatter tests can assert on the
+// printed table without changing the formatters' signatures.
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orig := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = orig }()
+
+	f()
+
+	w.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(out)
+}
+
+func TestFormatUsers(t *testing.T) {
+	body := []byte(`{"value":[{"displayName":"Alice","userName":"alice@example.com","active":true}]}`)
+
+	out := captureStdout(t, func() {
+		if err := formatUsers(body); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if !strings.Contains(out, "Alice") || !strings.Contains(out, "alice@example.com") {
+		t.Fatalf("got output %q, want it to mention the user's name and email", out)
+	}
+}
+
+func TestFormatResultDispatchesOnODataType(t *testing.T) {
+	body := []byte(`{"@odata.type":"#UserCollection.UserCollection","value":[{"displayName":"Bob","userName":"bob@example.com"}]}`)
+
+	out := captureStdout(t, func() {
+		if err := formatResult(body); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if !strings.Contains(out, "Name: Bob : Email: bob@example.com") {
+		t.Fatalf("got output %q, want it routed to formatUsers", out)
+	}
+}
+
+func TestFormatResultFallsBackToGeneric(t *testing.T) {
+	body := []byte(`{"@odata.type":"#SomethingElse.v1","Name":"widget"}`)
+
+	out := captureStdout(t, func() {
+		if err := formatResult(body); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if !strings.Contains(out, "Name: widget") {
+		t.Fatalf("got output %q, want formatGeneric's key/value tree", out)
+	}
+}
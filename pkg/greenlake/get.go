@@ -3,8 +3,8 @@
 package greenlake
 
 import (
-	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/HewlettPackard/hpecli/pkg/logger"
 	"github.com/spf13/cobra"
@@ -13,12 +13,16 @@ import (
 var (
 	getPath       string
 	getJSONResult bool
+	getSelect     string
+	getFilter     string
 )
 
 func init() {
-	glGetCmd.Flags().StringVar(&getPath, "path", "p", "path to a RedFish item")
+	glGetCmd.Flags().StringVar(&getPath, "path", "p", "path to a RedFish item, e.g. Users, Systems/1/Processors")
 	// glGetCmd.Flags().StringVar(&glLoginData.host, "host", "", "greenlake ip address")
 	glGetCmd.Flags().BoolVar(&getJSONResult, "json", false, "display result in json")
+	glGetCmd.Flags().StringVar(&getSelect, "select", "", "RedFish $select query, e.g. Name,Status")
+	glGetCmd.Flags().StringVar(&getFilter, "filter", "", "RedFish $filter query, e.g. Status/State eq 'Enabled'")
 	// _ = glGetCmd.MarkFlagRequired("host")
 	_ = glGetCmd.MarkFlagRequired("path")
 
@@ -36,28 +40,28 @@ func runGlGet(_ *cobra.Command, _ []string) error {
 	host, tenantID, apiKey := getTokenTenantID()
 	glc := NewGLClientFromAPIKey(host, tenantID, apiKey)
 
-	switch getPath {
-	case "users":
-		body, err := glc.GetUsers("Users")
-		if err != nil {
-			logger.Debug("unable to get the users with the supplied credentials: %v", err)
-			return err
-		}
-		if getJSONResult {
-			resstring := string(body)
-			fmt.Println(resstring)
-		} else {
-			var result []User
-			if err := json.Unmarshal(body, &result); err != nil {
-				return err
-			}
-			for _, user := range result {
-				fmt.Printf("Name: %s : Email: %s Active: %t\n", user.DisplayName, user.UserName, user.Active)
-			}
-		}
-
-	default:
-		fmt.Println("Unknown path: ", getPath)
+	path := resolvePath(getPath)
+
+	body, err := glc.Get(path, GetOptions{Select: getSelect, Filter: getFilter})
+	if err != nil {
+		logger.Debug("unable to get %q with the supplied credentials: %v", path, err)
+		return err
+	}
+
+	if getJSONResult {
+		fmt.Println(string(body))
+		return nil
+	}
+
+	return formatResult(body)
+}
+
+// resolvePath keeps the original "users" shortcut working while letting
+// --path be any RedFish resource path.
+func resolvePath(path string) string {
+	if strings.EqualFold(path, "users") {
+		return "Users"
 	}
-	return nil
+
+	return path
 }
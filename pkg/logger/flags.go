@@ -0,0 +1,39 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+package logger
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// AddPersistentFlags registers the --log-level and --log-format flags on
+// the root command and wires them to SetLevel/SetFormat so every
+// subcommand picks them up before it runs.
+func AddPersistentFlags(root *cobra.Command) {
+	var level, format string
+
+	root.PersistentFlags().StringVar(&level, "log-level", "info",
+		"log level: debug, info, warning, error")
+	root.PersistentFlags().StringVar(&format, "log-format", "text",
+		"log output format: text, json")
+
+	root.PersistentPreRunE = chainPreRunE(root.PersistentPreRunE, func(_ *cobra.Command, _ []string) error {
+		if err := SetLevel(level); err != nil {
+			return err
+		}
+
+		return SetFormat(format)
+	})
+}
+
+func chainPreRunE(first, second func(*cobra.Command, []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if first != nil {
+			if err := first(cmd, args); err != nil {
+				return err
+			}
+		}
+
+		return second(cmd, args)
+	}
+}
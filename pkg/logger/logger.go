@@ -0,0 +1,89 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+// Package logger is the single structured logging facade used across
+// hpecli. Every subcommand should log through here instead of calling
+// logrus directly, so level, output format and field redaction stay
+// consistent no matter which provider package is running.
+package logger
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.New()
+
+func init() {
+	log.AddHook(redactHook{})
+	_ = SetFormat("text")
+}
+
+// Fields is a set of structured key/value pairs attached to a log entry,
+// e.g. logger.WithFields(logger.Fields{"host": host}).Info("logged in").
+type Fields = logrus.Fields
+
+// Entry is a log line in progress, returned by WithFields.
+type Entry = logrus.Entry
+
+// SetLevel sets the minimum level that will be emitted. Valid values are
+// "debug", "info", "warning", "error", "fatal" and "panic".
+func SetLevel(level string) error {
+	l, err := logrus.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("unknown log level %q: %v", level, err)
+	}
+
+	log.SetLevel(l)
+
+	return nil
+}
+
+// SetFormat sets the output encoding, either "text" (the default, human
+// readable) or "json" (one object per line, suitable for log aggregators).
+func SetFormat(format string) error {
+	switch format {
+	case "json":
+		log.SetFormatter(&logrus.JSONFormatter{})
+	case "text", "":
+		log.SetFormatter(&logrus.TextFormatter{})
+	default:
+		return fmt.Errorf("unknown log format %q, expected \"text\" or \"json\"", format)
+	}
+
+	return nil
+}
+
+// SetOutput redirects where log entries are written. It defaults to
+// os.Stderr.
+func SetOutput(w io.Writer) {
+	log.SetOutput(w)
+}
+
+// WithFields starts a log entry carrying the given structured fields.
+// Fields named Authorization, X-API-Key, sessionID or token are redacted
+// before the entry is emitted.
+func WithFields(fields Fields) *Entry {
+	return log.WithFields(fields)
+}
+
+// Debug logs a formatted message at debug level.
+func Debug(format string, args ...interface{}) {
+	log.Debugf(format, args...)
+}
+
+// Info logs a formatted message at info level.
+func Info(format string, args ...interface{}) {
+	log.Infof(format, args...)
+}
+
+// Warning logs a formatted message at warning level.
+func Warning(format string, args ...interface{}) {
+	log.Warnf(format, args...)
+}
+
+// Error logs a formatted message at error level.
+func Error(format string, args ...interface{}) {
+	log.Errorf(format, args...)
+}
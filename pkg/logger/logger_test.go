@@ -0,0 +1,100 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRedactsSensitiveFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	SetOutput(&buf)
+	defer SetOutput(nil)
+
+	if err := SetFormat("json"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetLevel("info"); err != nil {
+		t.Fatal(err)
+	}
+
+	WithFields(Fields{
+		"Authorization": "Bearer secret-token",
+		"X-API-Key":     "abc123",
+		"sessionID":     "sess-456",
+		"token":         "tok-789",
+		"host":          "oneview.example.com",
+	}).Info("logged in")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unable to parse log line %q: %v", buf.String(), err)
+	}
+
+	for _, field := range []string{"Authorization", "X-API-Key", "sessionID", "token"} {
+		if entry[field] != redactedPlaceholder {
+			t.Fatalf("expected field %q to be redacted, got %v", field, entry[field])
+		}
+	}
+
+	if entry["host"] != "oneview.example.com" {
+		t.Fatalf("expected unrelated field %q to survive, got %v", "host", entry["host"])
+	}
+}
+
+func TestJSONSchemaIsStable(t *testing.T) {
+	var buf bytes.Buffer
+
+	SetOutput(&buf)
+	defer SetOutput(nil)
+
+	if err := SetFormat("json"); err != nil {
+		t.Fatal(err)
+	}
+
+	Info("greenlake/get called")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unable to parse log line %q: %v", buf.String(), err)
+	}
+
+	for _, key := range []string{"level", "msg", "time"} {
+		if _, ok := entry[key]; !ok {
+			t.Fatalf("expected json log entry to contain %q, got %v", key, entry)
+		}
+	}
+}
+
+func TestSetFormatRejectsUnknownFormat(t *testing.T) {
+	if err := SetFormat("yaml"); err == nil {
+		t.Fatal("expected an error for an unsupported format")
+	}
+}
+
+func TestSetLevelRejectsUnknownLevel(t *testing.T) {
+	if err := SetLevel("verbose"); err == nil {
+		t.Fatal("expected an error for an unsupported level")
+	}
+}
+
+func TestTextFormatDoesNotLeakRedactedFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	SetOutput(&buf)
+	defer SetOutput(nil)
+
+	if err := SetFormat("text"); err != nil {
+		t.Fatal(err)
+	}
+
+	WithFields(Fields{"token": "tok-789"}).Info("request made")
+
+	if strings.Contains(buf.String(), "tok-789") {
+		t.Fatalf("expected token to be redacted from text output, got %q", buf.String())
+	}
+}
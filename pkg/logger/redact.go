@@ -0,0 +1,40 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+package logger
+
+import (
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// redactedFieldNames are structured field names whose values are replaced
+// with a placeholder before an entry is emitted, regardless of format or
+// sink. Matching is case-insensitive since callers are inconsistent about
+// casing HTTP header names.
+var redactedFieldNames = map[string]bool{
+	"authorization": true,
+	"x-api-key":     true,
+	"sessionid":     true,
+	"token":         true,
+}
+
+const redactedPlaceholder = "REDACTED"
+
+// redactHook is a logrus.Hook that scrubs sensitive structured fields from
+// every log entry before it reaches a formatter or sink.
+type redactHook struct{}
+
+func (redactHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (redactHook) Fire(entry *logrus.Entry) error {
+	for k := range entry.Data {
+		if redactedFieldNames[strings.ToLower(k)] {
+			entry.Data[k] = redactedPlaceholder
+		}
+	}
+
+	return nil
+}
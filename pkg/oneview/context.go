@@ -0,0 +1,44 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+package oneview
+
+import (
+	sharedcontext "github.com/HewlettPackard/hpecli/pkg/context"
+)
+
+const providerName = "oneview"
+
+// saveHostData records a successful OneView login in the shared context
+// store, making it the current one.
+func saveHostData(host, token string) error {
+	return sharedcontext.Save(sharedcontext.Entry{
+		Provider: providerName,
+		Host:     host,
+		Token:    token,
+	})
+}
+
+// hostData returns the saved token for host.
+func hostData(host string) (token string, err error) {
+	e, err := sharedcontext.Get(providerName, host)
+	if err != nil {
+		return "", err
+	}
+
+	return e.Token, nil
+}
+
+// hostAndToken returns the host and token for the current OneView login.
+func hostAndToken() (host, token string, err error) {
+	e, err := sharedcontext.Current(providerName)
+	if err != nil {
+		return "", "", err
+	}
+
+	return e.Host, e.Token, nil
+}
+
+// deleteSavedHostData removes the saved login for host.
+func deleteSavedHostData(host string) error {
+	return sharedcontext.Remove(providerName, host)
+}
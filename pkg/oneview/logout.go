@@ -6,7 +6,7 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/sirupsen/logrus"
+	"github.com/HewlettPackard/hpecli/pkg/logger"
 	"github.com/spf13/cobra"
 )
 
@@ -33,28 +33,28 @@ func newLogoutCommand() *cobra.Command {
 func runLogout(hostParam string) error {
 	host, token, err := hostToLogout(hostParam)
 	if err != nil {
-		logrus.Debugf("unable to retrieve apiKey because of: %v", err)
+		logger.Debug("unable to retrieve apiKey because of: %v", err)
 		return fmt.Errorf("unable to retrieve the last login for OneView.  " +
 			"Please login to OneView using: hpecli oneview login")
 	}
 
 	ovc := newOVClientFromAPIKey(host, token)
 
-	logrus.Warningf("Using OneView: %s\n", host)
+	logger.Warning("Using OneView: %s", host)
 
 	// Use OVClient to logout
 	err = ovc.SessionLogout()
 	if err != nil {
-		logrus.Warningf("Unable to logout from OneView at: %s", host)
+		logger.Warning("Unable to logout from OneView at: %s", host)
 		return err
 	}
 
-	logrus.Warningf("Successfully logged out of OneView: %s", host)
+	logger.Warning("Successfully logged out of OneView: %s", host)
 
 	// Cleanup context
 	err = deleteSavedHostData(host)
 	if err != nil {
-		logrus.Warning("Unable to cleanup the session data")
+		logger.Warning("Unable to cleanup the session data")
 		return err
 	}
 
@@ -66,7 +66,7 @@ func hostToLogout(hostParam string) (host, token string, err error) {
 		// they didn't specify a host.. so use the context to find one
 		h, t, e := hostAndToken()
 		if e != nil {
-			logrus.Debugf("unable to retrieve apiKey because of: %v", e)
+			logger.Debug("unable to retrieve apiKey because of: %v", e)
 			return "", "", fmt.Errorf("unable to retrieve the last login for OneView.  " +
 				"Please login to OneView using: hpecli oneview login")
 		}
@@ -0,0 +1,282 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+package update
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/spf13/cobra"
+)
+
+// trustedPublicKey is the hex-encoded Ed25519 root embedded at build
+// time (via -ldflags), using the same encoding as the PublicKey served
+// in version.json. Every release signature must chain to it, so a
+// compromised download host can't silently swap in its own key.
+var trustedPublicKey string
+
+// NewCommand builds the "update" command and its subcommands. It is
+// registered on the root command alongside oneview, cloudvolume and
+// greenlake.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Check for and apply hpecli updates",
+	}
+
+	cmd.AddCommand(NewApplyCommand())
+
+	return cmd
+}
+
+// NewApplyCommand builds the "update apply" subcommand.
+func NewApplyCommand() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Download, verify and install the latest hpecli release",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if os.Getenv(EnvDisableUpdateCheck) != "" {
+				return fmt.Errorf("update apply skipped: %s is set", EnvDisableUpdateCheck)
+			}
+
+			resp, err := checkUpdate(&jsonSource{url: versionURL}, localVersion)
+			if err != nil {
+				return fmt.Errorf("unable to check for an update: %v", err)
+			}
+
+			proceed, err := resolveApplyDecision(localVersion, resp.RemoteVersion, resp.UpdateAvailable, force)
+			if err != nil {
+				return err
+			}
+
+			if !proceed {
+				fmt.Println("hpecli is already up to date")
+				return nil
+			}
+
+			exe, err := os.Executable()
+			if err != nil {
+				return fmt.Errorf("unable to determine the running executable: %v", err)
+			}
+
+			return apply(resp, exe)
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "apply the update even if it is a downgrade or no newer version is reported")
+
+	return cmd
+}
+
+// resolveApplyDecision decides whether an update apply should proceed. A
+// downgrade (remote older than local) is rejected unless force is set; a
+// remote that is neither newer nor older than local is a no-op unless
+// force is set.
+func resolveApplyDecision(localVer, remoteVer string, updateAvailable, force bool) (bool, error) {
+	if updateAvailable {
+		return true, nil
+	}
+
+	if force {
+		return true, nil
+	}
+
+	downgrade, err := versionGreaterThan(localVer, remoteVer)
+	if err == nil && downgrade {
+		return false, fmt.Errorf("refusing to downgrade from %s to %s; use --force to override", localVer, remoteVer)
+	}
+
+	return false, nil
+}
+
+// apply downloads the release described by resp, verifies it, and
+// atomically replaces the binary at exePath with it.
+func apply(resp *CheckResponse, exePath string) error {
+	if resp.URL == "" {
+		return fmt.Errorf("update manifest did not include a download url")
+	}
+
+	if len(resp.PublicKey) == 0 {
+		return fmt.Errorf("update manifest did not include a public key")
+	}
+
+	if trustedPublicKey == "" {
+		return fmt.Errorf("this build has no trusted public key embedded; refusing to trust a key supplied only by the download host")
+	}
+
+	trusted, err := hex.DecodeString(trustedPublicKey)
+	if err != nil {
+		return fmt.Errorf("trustedPublicKey build setting is not valid hex: %v", err)
+	}
+
+	if !bytes.Equal(resp.PublicKey, trusted) {
+		return fmt.Errorf("public key served in version.json does not match the trusted root; refusing to update")
+	}
+
+	binary, err := download(resp.URL)
+	if err != nil {
+		return fmt.Errorf("unable to download update: %v", err)
+	}
+
+	if err := verifyChecksum(binary, resp.CheckSum); err != nil {
+		return err
+	}
+
+	sigURL := resp.URL + ".sig"
+
+	signature, err := download(sigURL)
+	if err != nil {
+		return fmt.Errorf("unable to download signature %s: %v", sigURL, err)
+	}
+
+	if err := verifySignature([]byte(resp.PublicKey), binary, signature); err != nil {
+		return err
+	}
+
+	if err := replaceExecutable(exePath, binary); err != nil {
+		return err
+	}
+
+	return reExecFunc(exePath)
+}
+
+// reExecFunc performs the re-exec step after a successful update. It is a
+// var, like versionURL and trustedPublicKey, so tests can stub it out
+// instead of actually replacing the test process.
+var reExecFunc = reExec
+
+// reExec runs the newly-installed binary at exePath as a child process
+// with the current arguments and standard streams, then exits with its
+// exit code, so the update takes effect immediately instead of requiring
+// the user to relaunch hpecli by hand.
+func reExec(exePath string) error {
+	cmd := exec.Command(exePath, os.Args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			os.Exit(exitErr.ExitCode())
+		}
+		return fmt.Errorf("update installed, but re-exec into the new binary failed: %v", err)
+	}
+
+	os.Exit(0)
+
+	return nil
+}
+
+func download(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d retrieving %s", resp.StatusCode, url)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// verifyChecksum accepts either a sha256 or sha512 hex digest, chosen by
+// its length, matching the sums published alongside a release.
+func verifyChecksum(data []byte, checksum string) error {
+	want, err := hex.DecodeString(checksum)
+	if err != nil {
+		return fmt.Errorf("checksum in manifest is not valid hex: %v", err)
+	}
+
+	var got []byte
+
+	switch len(want) {
+	case sha256.Size:
+		sum := sha256.Sum256(data)
+		got = sum[:]
+	case sha512.Size:
+		sum := sha512.Sum512(data)
+		got = sum[:]
+	default:
+		return fmt.Errorf("checksum length %d does not match sha256 or sha512", len(want))
+	}
+
+	if hex.EncodeToString(got) != hex.EncodeToString(want) {
+		return fmt.Errorf("checksum mismatch: downloaded binary does not match the published checksum")
+	}
+
+	return nil
+}
+
+func verifySignature(publicKey, binary, signature []byte) error {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("public key is %d bytes, expected %d for ed25519", len(publicKey), ed25519.PublicKeySize)
+	}
+
+	if !ed25519.Verify(publicKey, binary, signature) {
+		return fmt.Errorf("signature verification failed: the downloaded binary may have been tampered with")
+	}
+
+	return nil
+}
+
+// replaceExecutable atomically swaps the running binary for newBinary.
+// It writes newBinary to a tempfile alongside the target, then renames it
+// into place. On Windows the current executable can't be removed while it
+// is running, so it is first renamed to a ".old" sibling instead.
+func replaceExecutable(exePath string, newBinary []byte) error {
+	dir := filepath.Dir(exePath)
+
+	tmp, err := ioutil.TempFile(dir, "hpecli-update-*")
+	if err != nil {
+		return fmt.Errorf("unable to create a tempfile next to %s: %v", exePath, err)
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := io.Copy(tmp, bytes.NewReader(newBinary)); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("unable to write the new binary: %v", err)
+	}
+
+	if err := tmp.Chmod(0o755); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("unable to mark the new binary executable: %v", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if runtime.GOOS == "windows" {
+		oldPath := exePath + ".old"
+		_ = os.Remove(oldPath)
+		if err := os.Rename(exePath, oldPath); err != nil {
+			os.Remove(tmpPath)
+			return fmt.Errorf("unable to move the running binary aside: %v", err)
+		}
+	}
+
+	if err := os.Rename(tmpPath, exePath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("unable to install the update: %v", err)
+	}
+
+	return nil
+}
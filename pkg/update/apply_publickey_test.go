@@ -0,0 +1,47 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+package update
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// TestCheckUpdatePublicKeyRoundTripsThroughJSON exercises the real JSON
+// parsing path (checkUpdate, not a struct literal) to make sure a
+// hex-encoded ed25519 public key, as version.json actually publishes it,
+// comes out the other end as exactly ed25519.PublicKeySize raw bytes
+// that verifySignature can use.
+func TestCheckUpdatePublicKeyRoundTripsThroughJSON(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(contentType, jsonType)
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"version":"0.1.0","publickey":"%s"}`, hex.EncodeToString(pub))
+	})
+	defer server.Close()
+
+	resp, err := checkUpdate(&jsonSource{url: versionURL}, "0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.PublicKey) != ed25519.PublicKeySize {
+		t.Fatalf("got a %d byte public key after JSON parsing, want %d", len(resp.PublicKey), ed25519.PublicKeySize)
+	}
+
+	binary := []byte("release binary contents")
+	sig := ed25519.Sign(priv, binary)
+
+	if err := verifySignature([]byte(resp.PublicKey), binary, sig); err != nil {
+		t.Fatalf("expected a valid signature to verify against the hex-decoded public key, got: %v", err)
+	}
+}
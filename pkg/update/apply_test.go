@@ -0,0 +1,226 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+package update
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestApplyBadSignature(t *testing.T) {
+	pub, priv := newKeyPair(t)
+	binary := []byte("new-binary-contents")
+	sig := ed25519.Sign(priv, binary)
+	sig[0] ^= 0xFF // corrupt the signature
+
+	defer withTrustedPublicKey(pub)()
+
+	server := newApplyTestServer(t, binary, sig)
+	defer server.Close()
+
+	resp := &CheckResponse{
+		URL:       server.URL + "/hpecli",
+		PublicKey: RawBytes(pub),
+		CheckSum:  hex.EncodeToString(sum256(binary)),
+	}
+
+	exe := filepath.Join(t.TempDir(), "hpecli")
+	if err := apply(resp, exe); err == nil {
+		t.Fatal("expected a signature verification error, got nil")
+	}
+}
+
+func TestApplyChecksumMismatch(t *testing.T) {
+	pub, priv := newKeyPair(t)
+	binary := []byte("new-binary-contents")
+	sig := ed25519.Sign(priv, binary)
+
+	defer withTrustedPublicKey(pub)()
+
+	server := newApplyTestServer(t, binary, sig)
+	defer server.Close()
+
+	resp := &CheckResponse{
+		URL:       server.URL + "/hpecli",
+		PublicKey: RawBytes(pub),
+		CheckSum:  hex.EncodeToString(sum256([]byte("not the binary"))),
+	}
+
+	exe := filepath.Join(t.TempDir(), "hpecli")
+	if err := apply(resp, exe); err == nil {
+		t.Fatal("expected a checksum mismatch error, got nil")
+	}
+}
+
+func TestResolveApplyDecision(t *testing.T) {
+	cases := []struct {
+		name            string
+		localVer        string
+		remoteVer       string
+		updateAvailable bool
+		force           bool
+		wantProceed     bool
+		wantErr         bool
+	}{
+		{"newer remote proceeds", "0.1.0", "0.2.0", true, false, true, false},
+		{"same version is a no-op", "0.1.0", "0.1.0", false, false, false, false},
+		{"downgrade rejected without force", "0.2.0", "0.1.0", false, false, false, true},
+		{"downgrade allowed with force", "0.2.0", "0.1.0", false, true, true, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			proceed, err := resolveApplyDecision(c.localVer, c.remoteVer, c.updateAvailable, c.force)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("got err %v, wantErr %v", err, c.wantErr)
+			}
+			if proceed != c.wantProceed {
+				t.Fatalf("got proceed %v, want %v", proceed, c.wantProceed)
+			}
+		})
+	}
+}
+
+func TestApplySuccessfulUpgrade(t *testing.T) {
+	pub, priv := newKeyPair(t)
+	binary := []byte("new-binary-contents")
+	sig := ed25519.Sign(priv, binary)
+
+	defer withTrustedPublicKey(pub)()
+
+	server := newApplyTestServer(t, binary, sig)
+	defer server.Close()
+
+	resp := &CheckResponse{
+		URL:       server.URL + "/hpecli",
+		PublicKey: RawBytes(pub),
+		CheckSum:  hex.EncodeToString(sum256(binary)),
+	}
+
+	dir := t.TempDir()
+	exe := filepath.Join(dir, "hpecli")
+	if err := ioutil.WriteFile(exe, []byte("old-binary-contents"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	reExecCalled := false
+	origReExec := reExecFunc
+	reExecFunc = func(string) error {
+		reExecCalled = true
+		return nil
+	}
+	defer func() { reExecFunc = origReExec }()
+
+	if err := apply(resp, exe); err != nil {
+		t.Fatalf("expected a successful update, got: %v", err)
+	}
+
+	got, err := ioutil.ReadFile(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != string(binary) {
+		t.Fatalf("executable was not replaced with the downloaded binary: got %q", got)
+	}
+
+	if !reExecCalled {
+		t.Fatal("apply did not re-exec into the newly installed binary")
+	}
+}
+
+func TestApplyRequiresATrustedPublicKey(t *testing.T) {
+	pub, priv := newKeyPair(t)
+	binary := []byte("new-binary-contents")
+	sig := ed25519.Sign(priv, binary)
+
+	server := newApplyTestServer(t, binary, sig)
+	defer server.Close()
+
+	resp := &CheckResponse{
+		URL:       server.URL + "/hpecli",
+		PublicKey: RawBytes(pub),
+		CheckSum:  hex.EncodeToString(sum256(binary)),
+	}
+
+	exe := filepath.Join(t.TempDir(), "hpecli")
+	if err := apply(resp, exe); err == nil {
+		t.Fatal("expected apply to refuse to run with no trustedPublicKey embedded in the build, got nil")
+	}
+}
+
+func TestApplyRejectsAForgedKeyNotMatchingTheTrustedRoot(t *testing.T) {
+	rootPub, _ := newKeyPair(t)
+	defer withTrustedPublicKey(rootPub)()
+
+	// An attacker who controls (or MITMs) the download host generates their
+	// own keypair and signs a malicious binary with it. The manifest they
+	// serve vouches for their own key, not the one embedded in this build.
+	attackerPub, attackerPriv := newKeyPair(t)
+	binary := []byte("attacker-controlled-binary-contents")
+	sig := ed25519.Sign(attackerPriv, binary)
+
+	server := newApplyTestServer(t, binary, sig)
+	defer server.Close()
+
+	resp := &CheckResponse{
+		URL:       server.URL + "/hpecli",
+		PublicKey: RawBytes(attackerPub),
+		CheckSum:  hex.EncodeToString(sum256(binary)),
+	}
+
+	exe := filepath.Join(t.TempDir(), "hpecli")
+	if err := apply(resp, exe); err == nil {
+		t.Fatal("expected apply to reject a key that doesn't match the trusted root, got nil")
+	}
+
+	if got, err := ioutil.ReadFile(exe); err == nil {
+		t.Fatalf("attacker-signed binary was installed: %q", got)
+	}
+}
+
+// withTrustedPublicKey points trustedPublicKey at pub for the duration of a
+// test and returns a func to restore it, so apply's pinning check has
+// something to compare resp.PublicKey against.
+func withTrustedPublicKey(pub ed25519.PublicKey) func() {
+	orig := trustedPublicKey
+	trustedPublicKey = hex.EncodeToString(pub)
+	return func() { trustedPublicKey = orig }
+}
+
+func newKeyPair(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pub, priv
+}
+
+func sum256(b []byte) []byte {
+	sum := sha256.Sum256(b)
+	return sum[:]
+}
+
+func newApplyTestServer(t *testing.T, binary, signature []byte) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hpecli", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(binary)
+	})
+	mux.HandleFunc("/hpecli.sig", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(signature)
+	})
+
+	return httptest.NewServer(mux)
+}
@@ -0,0 +1,172 @@
+// (C) Copyright 2019 Hewlett Packard Enterprise Development LP.
+
+package update
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// EnvDisableUpdateCheck is the environment variable that, when set to any
+// non-empty value, disables all update checking and self-update behavior.
+const EnvDisableUpdateCheck = "HPECLI_DISABLE_UPDATE_CHECK"
+
+const versionPath = "/version.json"
+
+// versionURL is the location of the version manifest published for each
+// release. It is a var (rather than a const) so tests can point it at an
+// httptest.Server.
+var versionURL = "https://raw.githubusercontent.com/HewlettPackard/hpecli/master/version.json"
+
+// localVersion is replaced at build time via -ldflags to the actual
+// released version. It defaults to a baseline so development builds can
+// still compare against a remote version.
+var localVersion = "0.0.0"
+
+// CheckResponse describes the result of checking for an update, including
+// everything needed to later verify and apply it.
+type CheckResponse struct {
+	UpdateAvailable bool     `json:"-"`
+	RemoteVersion   string   `json:"version"`
+	Message         string   `json:"message"`
+	URL             string   `json:"url"`
+	PublicKey       RawBytes `json:"publickey"`
+	CheckSum        string   `json:"checksum"`
+}
+
+// RawBytes unmarshals a JSON string field as hex, rather than base64 (the
+// encoding/json default for []byte). The version manifest carries the
+// pinned public key as a hex string, the same encoding CheckSum already
+// uses.
+type RawBytes []byte
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *RawBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("publickey is not valid hex: %v", err)
+	}
+
+	*r = RawBytes(b)
+
+	return nil
+}
+
+// versionSource fetches the raw bytes of the version manifest. It exists so
+// tests can stub out the network call.
+type versionSource interface {
+	get() ([]byte, error)
+}
+
+type jsonSource struct {
+	url string
+}
+
+func (j *jsonSource) get() ([]byte, error) {
+	resp, err := http.Get(j.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d retrieving %s", resp.StatusCode, j.url)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// IsUpdateAvailable checks the published version manifest and reports
+// whether a newer version of hpecli is available. Any error (network,
+// parsing, disabled via env var, ...) results in false.
+func IsUpdateAvailable() bool {
+	resp, err := checkUpdate(&jsonSource{url: versionURL}, localVersion)
+	if err != nil {
+		return false
+	}
+
+	return resp.UpdateAvailable
+}
+
+// checkUpdate fetches the version manifest from src and compares it against
+// localVer, returning the populated CheckResponse.
+func checkUpdate(src versionSource, localVer string) (*CheckResponse, error) {
+	if strings.TrimSpace(os.Getenv(EnvDisableUpdateCheck)) != "" {
+		return &CheckResponse{}, nil
+	}
+
+	if localVer == "" {
+		return nil, fmt.Errorf("no local version supplied to compare against")
+	}
+
+	body, err := src.get()
+	if err != nil {
+		return nil, err
+	}
+
+	var resp CheckResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp.RemoteVersion == "" {
+		return nil, fmt.Errorf("remote version manifest did not contain a version")
+	}
+
+	greater, err := versionGreaterThan(resp.RemoteVersion, localVer)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.UpdateAvailable = greater
+
+	return &resp, nil
+}
+
+// versionGreaterThan reports whether remote is a newer dotted version
+// (major.minor.patch) than local.
+func versionGreaterThan(remote, local string) (bool, error) {
+	r, err := parseVersion(remote)
+	if err != nil {
+		return false, err
+	}
+
+	l, err := parseVersion(local)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range r {
+		if r[i] != l[i] {
+			return r[i] > l[i], nil
+		}
+	}
+
+	return false, nil
+}
+
+func parseVersion(v string) ([3]int, error) {
+	var parts [3]int
+
+	segments := strings.SplitN(v, ".", 3)
+	for i, s := range segments {
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return parts, fmt.Errorf("unable to parse version %q: %v", v, err)
+		}
+		parts[i] = n
+	}
+
+	return parts, nil
+}
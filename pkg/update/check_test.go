@@ -110,7 +110,7 @@ func TestCheckUpdate(t *testing.T) {
 				RemoteVersion:   "0.1.1",
 				Message:         "update available",
 				URL:             "https://foo.bar/update",
-				PublicKey:       []byte("00001111"),
+				PublicKey:       []byte{0x00, 0x00, 0x11, 0x11},
 				CheckSum:        "120EA8A25E5D487BF68B5F7096440019",
 			},
 		},